@@ -0,0 +1,38 @@
+package fiber_tracing
+
+import "strings"
+
+// defaultBodyDumpContentTypes is used when Config.BodyDumpContentTypes is
+// left empty: only these prefixes are captured, everything else (images,
+// octet-streams, multipart uploads, ...) is skipped to avoid dumping binary
+// payloads into spans.
+var defaultBodyDumpContentTypes = []string{
+	"application/json",
+	"application/xml",
+	"text/",
+}
+
+// isDumpableContentType reports whether contentType matches one of types,
+// ignoring parameters such as charset.
+func isDumpableContentType(contentType string, types []string) bool {
+	if len(types) == 0 {
+		types = defaultBodyDumpContentTypes
+	}
+
+	for _, t := range types {
+		if strings.HasPrefix(contentType, t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// truncateBody truncates body to maxSize bytes. maxSize <= 0 means unlimited.
+func truncateBody(body []byte, maxSize int) []byte {
+	if maxSize <= 0 || len(body) <= maxSize {
+		return body
+	}
+
+	return body[:maxSize]
+}
@@ -0,0 +1,50 @@
+package fiber_tracing
+
+import "testing"
+
+func TestIsDumpableContentType(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		types       []string
+		want        bool
+	}{
+		{"default json", "application/json; charset=utf-8", nil, true},
+		{"default text", "text/plain", nil, true},
+		{"default binary rejected", "image/png", nil, false},
+		{"custom type allowed", "application/octet-stream", []string{"application/octet-stream"}, true},
+		{"custom type rejected", "application/octet-stream", []string{"application/json"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isDumpableContentType(tt.contentType, tt.types); got != tt.want {
+				t.Errorf("isDumpableContentType(%q, %v) = %v, want %v", tt.contentType, tt.types, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTruncateBody(t *testing.T) {
+	body := []byte("hello world")
+
+	tests := []struct {
+		name    string
+		maxSize int
+		want    string
+	}{
+		{"unlimited", 0, "hello world"},
+		{"negative means unlimited", -1, "hello world"},
+		{"larger than body", 100, "hello world"},
+		{"truncates", 5, "hello"},
+		{"exact length", len(body), "hello world"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(truncateBody(body, tt.maxSize)); got != tt.want {
+				t.Errorf("truncateBody(%q, %d) = %q, want %q", body, tt.maxSize, got, tt.want)
+			}
+		})
+	}
+}
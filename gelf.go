@@ -0,0 +1,167 @@
+package fiber_tracing
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	// gelfChunkMagicByte1 and gelfChunkMagicByte2 are the two magic bytes
+	// GELF UDP chunks start with.
+	gelfChunkMagicByte1 = 0x1e
+	gelfChunkMagicByte2 = 0x0f
+
+	// gelfMaxChunkSize keeps chunks within the WAN-safe UDP MTU (8192 bytes)
+	// once the 12-byte chunk header is accounted for.
+	gelfMaxChunkSize = 8180
+
+	// gelfMaxChunks is the GELF protocol's hard limit on chunks per message.
+	gelfMaxChunks = 128
+
+	// syslog severity levels used for the GELF "level" field.
+	gelfLevelError = 3
+	gelfLevelInfo  = 6
+)
+
+// gelfMessage is a GELF 1.1 payload (https://docs.graylog.org/docs/gelf),
+// extended with underscore-prefixed custom fields describing the span.
+type gelfMessage struct {
+	Version      string  `json:"version"`
+	Host         string  `json:"host"`
+	ShortMessage string  `json:"short_message"`
+	Timestamp    float64 `json:"timestamp"`
+	Level        int32   `json:"level"`
+	Facility     string  `json:"_facility"`
+	TraceID      string  `json:"_trace_id"`
+	SpanID       string  `json:"_span_id"`
+	SpanName     string  `json:"_span_name"`
+	DurationMS   float64 `json:"_duration_ms"`
+	StatusCode   string  `json:"_status_code"`
+}
+
+// gelfSpanExporter is the sdktrace.SpanExporter returned by gelfExporter. It
+// marshals each span as a GELF message, gzip-compresses it, and writes it to
+// conn, splitting into GELF chunks when the compressed payload would exceed
+// a single UDP datagram.
+type gelfSpanExporter struct {
+	conn net.Conn
+	host string
+}
+
+func (e *gelfSpanExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	for _, span := range spans {
+		payload, err := json.Marshal(gelfMessageFromSpan(span, e.host))
+		if err != nil {
+			return fmt.Errorf("fiber_tracing: marshal gelf message: %w", err)
+		}
+
+		if err := e.send(payload); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *gelfSpanExporter) Shutdown(ctx context.Context) error {
+	return e.conn.Close()
+}
+
+func gelfMessageFromSpan(span sdktrace.ReadOnlySpan, host string) gelfMessage {
+	sc := span.SpanContext()
+	status := span.Status()
+
+	level := int32(gelfLevelInfo)
+	if status.Code == codes.Error {
+		level = gelfLevelError
+	}
+
+	return gelfMessage{
+		Version:      "1.1",
+		Host:         host,
+		ShortMessage: span.Name(),
+		Timestamp:    float64(span.StartTime().UnixNano()) / float64(time.Second),
+		Level:        level,
+		Facility:     DefaultComponentName,
+		TraceID:      sc.TraceID().String(),
+		SpanID:       sc.SpanID().String(),
+		SpanName:     span.Name(),
+		DurationMS:   float64(span.EndTime().Sub(span.StartTime())) / float64(time.Millisecond),
+		StatusCode:   status.Code.String(),
+	}
+}
+
+// send gzip-compresses payload and writes it to the UDP connection, chunking
+// it per the GELF spec if it doesn't fit in one datagram.
+func (e *gelfSpanExporter) send(payload []byte) error {
+	compressed, err := gzipCompress(payload)
+	if err != nil {
+		return fmt.Errorf("fiber_tracing: compress gelf message: %w", err)
+	}
+
+	if len(compressed) <= gelfMaxChunkSize {
+		_, err := e.conn.Write(compressed)
+		return err
+	}
+
+	return e.sendChunked(compressed)
+}
+
+func gzipCompress(payload []byte) ([]byte, error) {
+	var buf bytes.Buffer
+
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(payload); err != nil {
+		return nil, err
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// sendChunked splits payload into GELF chunks: 2 magic bytes, an 8 byte
+// message id shared by every chunk, a sequence number and sequence count
+// byte, then the chunk's slice of the payload.
+func (e *gelfSpanExporter) sendChunked(payload []byte) error {
+	chunkCount := (len(payload) + gelfMaxChunkSize - 1) / gelfMaxChunkSize
+	if chunkCount > gelfMaxChunks {
+		return fmt.Errorf("fiber_tracing: gelf message needs %d chunks, exceeds max %d", chunkCount, gelfMaxChunks)
+	}
+
+	messageID := make([]byte, 8)
+	if _, err := rand.Read(messageID); err != nil {
+		return fmt.Errorf("fiber_tracing: generate gelf message id: %w", err)
+	}
+
+	for i := 0; i < chunkCount; i++ {
+		start := i * gelfMaxChunkSize
+		end := start + gelfMaxChunkSize
+		if end > len(payload) {
+			end = len(payload)
+		}
+
+		chunk := make([]byte, 0, 12+end-start)
+		chunk = append(chunk, gelfChunkMagicByte1, gelfChunkMagicByte2)
+		chunk = append(chunk, messageID...)
+		chunk = append(chunk, byte(i), byte(chunkCount))
+		chunk = append(chunk, payload[start:end]...)
+
+		if _, err := e.conn.Write(chunk); err != nil {
+			return fmt.Errorf("fiber_tracing: write gelf chunk: %w", err)
+		}
+	}
+
+	return nil
+}
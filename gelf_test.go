@@ -0,0 +1,116 @@
+package fiber_tracing
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestGzipCompressRoundTrip(t *testing.T) {
+	payload := []byte(`{"short_message":"hello"}`)
+
+	compressed, err := gzipCompress(payload)
+	if err != nil {
+		t.Fatalf("gzipCompress: %v", err)
+	}
+
+	r, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		t.Fatalf("gzip.NewReader: %v", err)
+	}
+	defer r.Close()
+
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("read gzip: %v", err)
+	}
+
+	if !bytes.Equal(got, payload) {
+		t.Fatalf("got %q, want %q", got, payload)
+	}
+}
+
+func TestSendChunkedWritesGELFChunkHeaders(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+
+	e := &gelfSpanExporter{conn: client, host: "test-host"}
+
+	payload := bytes.Repeat([]byte("a"), gelfMaxChunkSize*2+10)
+	wantChunks := 3
+
+	done := make(chan error, 1)
+	go func() { done <- e.sendChunked(payload) }()
+
+	var messageID []byte
+	for i := 0; i < wantChunks; i++ {
+		buf := make([]byte, gelfMaxChunkSize+12)
+		n, err := server.Read(buf)
+		if err != nil {
+			t.Fatalf("read chunk %d: %v", i, err)
+		}
+		chunk := buf[:n]
+
+		if chunk[0] != gelfChunkMagicByte1 || chunk[1] != gelfChunkMagicByte2 {
+			t.Fatalf("chunk %d: bad magic bytes %x %x", i, chunk[0], chunk[1])
+		}
+
+		if messageID == nil {
+			messageID = append([]byte(nil), chunk[2:10]...)
+		} else if !bytes.Equal(messageID, chunk[2:10]) {
+			t.Fatalf("chunk %d: message id changed mid-sequence", i)
+		}
+
+		if int(chunk[10]) != i {
+			t.Fatalf("chunk %d: sequence number = %d, want %d", i, chunk[10], i)
+		}
+
+		if int(chunk[11]) != wantChunks {
+			t.Fatalf("chunk %d: sequence count = %d, want %d", i, chunk[11], wantChunks)
+		}
+	}
+
+	if err := <-done; err != nil {
+		t.Fatalf("sendChunked: %v", err)
+	}
+}
+
+func TestGelfMessageFromSpanHasRequiredFields(t *testing.T) {
+	exp := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp))
+	defer tp.Shutdown(context.Background())
+
+	_, span := tp.Tracer("test").Start(context.Background(), "test-span")
+	span.End()
+
+	spans := exp.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+
+	msg := gelfMessageFromSpan(spans[0].Snapshot(), "test-host")
+
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(payload, &decoded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	for _, field := range []string{"version", "host", "short_message", "timestamp", "level"} {
+		if _, ok := decoded[field]; !ok {
+			t.Fatalf("missing required GELF field %q", field)
+		}
+	}
+}
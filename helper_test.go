@@ -0,0 +1,66 @@
+package fiber_tracing
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+)
+
+func TestChildSpanFromContextUsesParentWhenPresent(t *testing.T) {
+	tracer := mocktracer.New()
+	opentracing.SetGlobalTracer(tracer)
+
+	app := fiber.New()
+	app.Get("/", func(ctx *fiber.Ctx) error {
+		parent := tracer.StartSpan("parent").(*mocktracer.MockSpan)
+		ctx.Locals(DefaultParentSpanKey, parent)
+
+		child := ChildSpanFromContext(ctx, "child")
+		defer child.Finish()
+
+		mockChild, ok := child.(*mocktracer.MockSpan)
+		if !ok {
+			t.Fatalf("child span is %T, want *mocktracer.MockSpan", child)
+		}
+
+		if mockChild.ParentID != parent.SpanContext.SpanID {
+			t.Errorf("child span parent id = %d, want %d", mockChild.ParentID, parent.SpanContext.SpanID)
+		}
+
+		return nil
+	})
+
+	req := httptest.NewRequest("GET", "/", nil)
+	if _, err := app.Test(req); err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+}
+
+func TestChildSpanFromContextStartsRootWhenNoParent(t *testing.T) {
+	opentracing.SetGlobalTracer(mocktracer.New())
+
+	app := fiber.New()
+	app.Get("/", func(ctx *fiber.Ctx) error {
+		span := ChildSpanFromContext(ctx, "root")
+		defer span.Finish()
+
+		mockSpan, ok := span.(*mocktracer.MockSpan)
+		if !ok {
+			t.Fatalf("span is %T, want *mocktracer.MockSpan", span)
+		}
+
+		if mockSpan.ParentID != 0 {
+			t.Errorf("span parent id = %d, want 0 (root span)", mockSpan.ParentID)
+		}
+
+		return nil
+	})
+
+	req := httptest.NewRequest("GET", "/", nil)
+	if _, err := app.Test(req); err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+}
@@ -0,0 +1,175 @@
+package fiber_tracing
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/opentracing/opentracing-go"
+	"github.com/uber/jaeger-client-go/config"
+	"github.com/uber/jaeger-client-go/zipkin"
+)
+
+// Propagator identifies a wire format used to inject/extract span context
+// across process boundaries.
+type Propagator string
+
+const (
+	// PropagatorJaeger is jaeger-client-go's native uber-trace-id header. It
+	// is registered by default, even when not listed, for every carrier
+	// format no other listed propagator takes over.
+	PropagatorJaeger Propagator = "jaeger"
+
+	// PropagatorB3 is the Zipkin B3 header format.
+	PropagatorB3 Propagator = "b3"
+
+	// PropagatorW3C is the W3C traceparent/tracestate header format.
+	PropagatorW3C Propagator = "w3c"
+)
+
+// ErrUnsupportedPropagator is returned by NewWithJaegerTracerConfig when
+// JaegerOptions.Propagators names a format jaeger-client-go cannot register.
+// Notably jaeger-client-go has no built-in W3C trace-context support, so
+// PropagatorW3C is rejected rather than silently ignored; use the OTel
+// middleware in otel.go for W3C propagation.
+var ErrUnsupportedPropagator = errors.New("fiber_tracing: unsupported propagator")
+
+// JaegerOptions configures NewWithJaegerTracerConfig. Any zero-valued field
+// falls back to the package defaults, and the resulting config is merged
+// with config.FromEnv() so existing JAEGER_* env vars keep working.
+type JaegerOptions struct {
+	// ServiceName identifies this service in Jaeger.
+	// Default: "fiber-tracer"
+	ServiceName string
+
+	// SamplerType is one of "const", "probabilistic", "ratelimiting" or
+	// "remote".
+	// Default: "const"
+	SamplerType string
+
+	// SamplerParam is interpreted according to SamplerType, e.g. 0 or 1 for
+	// "const", a probability for "probabilistic". A nil pointer means
+	// "unset"; an explicit 0 (e.g. "never sample" for the const sampler) is
+	// honored rather than overwritten.
+	// Default: 1
+	SamplerParam *float64
+
+	// LocalAgentHostPort is the host:port of the jaeger-agent spans are
+	// reported to over UDP.
+	// Optional.
+	LocalAgentHostPort string
+
+	// CollectorEndpoint is the HTTP endpoint of a jaeger-collector spans are
+	// reported to directly, bypassing the agent.
+	// Optional.
+	CollectorEndpoint string
+
+	// LogSpans logs every span finished by the reporter. A nil pointer means
+	// "unset"; an explicit false is honored rather than overwritten.
+	// Default: true
+	LogSpans *bool
+
+	// BufferFlushInterval controls how often buffered spans are flushed to
+	// the reporter.
+	// Default: 1s
+	BufferFlushInterval time.Duration
+
+	// Propagators lists the wire formats to register injectors/extractors
+	// for. PropagatorB3 replaces PropagatorJaeger for the HTTP headers
+	// format; the two are not combined, so listing both injects and
+	// extracts only B3 headers over HTTP.
+	// Default: nil (PropagatorJaeger only)
+	Propagators []Propagator
+}
+
+// NewWithJaegerTracerConfig creates a Jaeger tracer from opts, merges it with
+// config.FromEnv() so JAEGER_* environment variables still apply, registers
+// the requested Propagators, and attaches the tracing middleware to f.
+// Unlike NewWithJaegerTracer it returns a typed error instead of panicking,
+// so callers can decide how to handle init failures.
+func NewWithJaegerTracerConfig(f *fiber.App, opts JaegerOptions) (io.Closer, error) {
+	if opts.ServiceName == "" {
+		opts.ServiceName = "fiber-tracer"
+	}
+
+	if opts.SamplerType == "" {
+		opts.SamplerType = "const"
+	}
+
+	if opts.BufferFlushInterval == 0 {
+		opts.BufferFlushInterval = 1 * time.Second
+	}
+
+	defcfg := config.Configuration{
+		ServiceName: opts.ServiceName,
+		Sampler: &config.SamplerConfig{
+			Type:  opts.SamplerType,
+			Param: resolveSamplerParam(opts.SamplerParam),
+		},
+		Reporter: &config.ReporterConfig{
+			LogSpans:            resolveLogSpans(opts.LogSpans),
+			BufferFlushInterval: opts.BufferFlushInterval,
+			LocalAgentHostPort:  opts.LocalAgentHostPort,
+			CollectorEndpoint:   opts.CollectorEndpoint,
+		},
+	}
+
+	cfg, err := defcfg.FromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("fiber_tracing: parse jaeger env vars: %w", err)
+	}
+
+	tracerOpts := make([]config.Option, 0, len(opts.Propagators))
+
+	for _, p := range opts.Propagators {
+		switch p {
+		case PropagatorJaeger:
+			// jaeger-client-go registers its native format for every
+			// carrier format not already set by an option below.
+		case PropagatorB3:
+			b3 := zipkin.NewZipkinB3HTTPHeaderPropagator()
+			tracerOpts = append(tracerOpts,
+				config.Injector(opentracing.HTTPHeaders, b3),
+				config.Extractor(opentracing.HTTPHeaders, b3),
+			)
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedPropagator, p)
+		}
+	}
+
+	tracer, closer, err := cfg.NewTracer(tracerOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("fiber_tracing: initialize jaeger tracer: %w", err)
+	}
+
+	opentracing.SetGlobalTracer(tracer)
+
+	f.Use(NewWithConfig(Config{
+		Tracer: tracer,
+	}))
+
+	return closer, nil
+}
+
+// resolveSamplerParam returns the configured sampler param, falling back to
+// 1 only when p is nil. An explicit 0 (e.g. "never sample" for the const
+// sampler) is returned as-is.
+func resolveSamplerParam(p *float64) float64 {
+	if p != nil {
+		return *p
+	}
+
+	return 1
+}
+
+// resolveLogSpans returns the configured LogSpans setting, falling back to
+// true only when p is nil. An explicit false is returned as-is.
+func resolveLogSpans(p *bool) bool {
+	if p != nil {
+		return *p
+	}
+
+	return true
+}
@@ -0,0 +1,49 @@
+package fiber_tracing
+
+import "testing"
+
+func TestResolveSamplerParam(t *testing.T) {
+	zero := 0.0
+	half := 0.5
+
+	tests := []struct {
+		name string
+		in   *float64
+		want float64
+	}{
+		{"nil falls back to default", nil, 1},
+		{"explicit zero is honored, not clobbered", &zero, 0},
+		{"explicit non-default value is honored", &half, 0.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolveSamplerParam(tt.in); got != tt.want {
+				t.Errorf("resolveSamplerParam(%v) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveLogSpans(t *testing.T) {
+	no := false
+	yes := true
+
+	tests := []struct {
+		name string
+		in   *bool
+		want bool
+	}{
+		{"nil falls back to default", nil, true},
+		{"explicit false is honored, not clobbered", &no, false},
+		{"explicit true is honored", &yes, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolveLogSpans(tt.in); got != tt.want {
+				t.Errorf("resolveLogSpans(%v) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
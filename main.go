@@ -42,6 +42,37 @@ var (
 	}
 )
 
+// routeTemplateOperationName is used as OperationName when Config.UseRouteTemplate
+// is set and the caller did not supply their own.
+func routeTemplateOperationName(ctx *fiber.Ctx) string {
+	return "HTTP " + ctx.Method() + " URL: " + routeOrPath(ctx)
+}
+
+// routeTemplateModify is used as Modify when Config.UseRouteTemplate is set
+// and the caller did not supply their own. It tags http.path with the route
+// template and the concrete path separately as http.target.
+func routeTemplateModify(ctx *fiber.Ctx, span opentracing.Span) {
+	ext.HTTPMethod.Set(span, ctx.Method())
+	ext.HTTPUrl.Set(span, ctx.OriginalURL())
+	ext.Component.Set(span, DefaultComponentName)
+
+	span.SetTag("http.remote_addr", ctx.IP())
+	span.SetTag("http.path", routeOrPath(ctx))
+	span.SetTag("http.target", ctx.Path())
+	span.SetTag("http.host", ctx.Hostname())
+}
+
+// routeOrPath returns the template of the route ctx.Route() reports, e.g.
+// "/users/:id", or falls back to the concrete request path when that route
+// is nil or has an empty path.
+func routeOrPath(ctx *fiber.Ctx) string {
+	if route := ctx.Route(); route != nil && route.Path != "" {
+		return route.Path
+	}
+
+	return ctx.Path()
+}
+
 // Config ...
 type Config struct {
 	// Tracer
@@ -65,8 +96,41 @@ type Config struct {
 	// Optional. Default: nil
 	Filter func(*fiber.Ctx) bool
 
+	// SkipSpanWithoutParent skips starting a span for requests that carry no
+	// incoming trace context, avoiding the cost of root-span creation for
+	// untraced ingress traffic. Common in service meshes where only
+	// mesh-originated requests should be sampled.
+	// Optional. Default: false
+	SkipSpanWithoutParent bool
+
+	// UseRouteTemplate uses the matched route's template (e.g. "/users/:id")
+	// instead of the concrete request path for the operation name and the
+	// http.path tag, keeping the span name low-cardinality. The concrete
+	// path is still tagged separately as http.target. The template is read
+	// after the request has been handled. When no handler route matched
+	// (a 404), the last route fiber matched is used, which may be a
+	// middleware's mount path such as "/"; the raw path is used only when
+	// fiber reports no route at all.
+	// Optional. Default: false
+	UseRouteTemplate bool
+
 	// Modify
 	Modify func(*fiber.Ctx, opentracing.Span)
+
+	// IsBodyDump enables capturing of request/response bodies as the
+	// http.request.body and http.response.body tags.
+	// Optional. Default: false
+	IsBodyDump bool
+
+	// MaxBodySize caps how many bytes of a dumped body are attached to the
+	// span. Bodies larger than this are truncated.
+	// Optional. Default: 0 (unlimited)
+	MaxBodySize int
+
+	// BodyDumpContentTypes restricts body dumping to these content-type
+	// prefixes, skipping binary payloads such as images or octet-streams.
+	// Optional. Default: []string{"application/json", "application/xml", "text/"}
+	BodyDumpContentTypes []string
 }
 
 // New returns a Trace middleware.
@@ -134,11 +198,19 @@ func NewWithConfig(config ...Config) fiber.Handler {
 	}
 
 	if cfg.Modify == nil {
-		cfg.Modify = DefaultConfig.Modify
+		if cfg.UseRouteTemplate {
+			cfg.Modify = routeTemplateModify
+		} else {
+			cfg.Modify = DefaultConfig.Modify
+		}
 	}
 
 	if cfg.OperationName == nil {
-		cfg.OperationName = DefaultConfig.OperationName
+		if cfg.UseRouteTemplate {
+			cfg.OperationName = routeTemplateOperationName
+		} else {
+			cfg.OperationName = DefaultConfig.OperationName
+		}
 	}
 
 	return func(ctx *fiber.Ctx) error {
@@ -157,15 +229,29 @@ func NewWithConfig(config ...Config) fiber.Handler {
 			hdr.Set(getString(k), getString(v))
 		})
 
-		if ctx, err := tr.Extract(opentracing.HTTPHeaders, opentracing.HTTPHeadersCarrier(hdr)); err != nil {
+		spanCtx, err := tr.Extract(opentracing.HTTPHeaders, opentracing.HTTPHeadersCarrier(hdr))
+		if err != nil {
+			if cfg.SkipSpanWithoutParent {
+				return ctx.Next()
+			}
+
 			span = tr.StartSpan(operationName)
 		} else {
-			span = tr.StartSpan(operationName, ext.RPCServerOption(ctx))
+			span = tr.StartSpan(operationName, ext.RPCServerOption(spanCtx))
 		}
 
 		cfg.Modify(ctx, span)
 
-		var err error
+		if cfg.IsBodyDump {
+			reqBody := ctx.Request().Body()
+			if isDumpableContentType(getString(ctx.Request().Header.ContentType()), cfg.BodyDumpContentTypes) {
+				// string() copies; getString's unsafe cast would alias fasthttp's
+				// pooled buffer, which jaeger-client-go's async reporter can read
+				// from after it's been reused by a later request.
+				span.SetTag("http.request.body", string(truncateBody(reqBody, cfg.MaxBodySize)))
+			}
+		}
+
 		defer func() {
 			status := ctx.Response().StatusCode()
 
@@ -197,6 +283,20 @@ func NewWithConfig(config ...Config) fiber.Handler {
 				ext.Error.Set(span, true)
 			}
 
+			if cfg.IsBodyDump && !ctx.Response().IsBodyStream() {
+				respBody := ctx.Response().Body()
+				if isDumpableContentType(getString(ctx.Response().Header.ContentType()), cfg.BodyDumpContentTypes) {
+					span.SetTag("http.response.body", string(truncateBody(respBody, cfg.MaxBodySize)))
+				}
+			}
+
+			if cfg.UseRouteTemplate {
+				// Before ctx.Next() ctx.Route() is this middleware's own route;
+				// only now does it report the handler route that matched.
+				span.SetOperationName(cfg.OperationName(ctx))
+				span.SetTag("http.path", routeOrPath(ctx))
+			}
+
 			span.Finish()
 		}()
 
@@ -0,0 +1,153 @@
+package fiber_tracing
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// DefaultOTelSpanKey is the ctx.Locals key the OTel middleware stashes
+	// the current span under, mirroring DefaultParentSpanKey for OpenTracing.
+	DefaultOTelSpanKey = "#defaultTracingOTelSpanKey"
+)
+
+var tracerProvider *sdktrace.TracerProvider
+
+// TraceExporter is implemented by the exporter constructors in otel_exporters.go
+// (OTLP/HTTP, stdout, GELF) and by any custom exporter a caller wants to plug
+// into the pipeline built by NewOTelMiddleware.
+type TraceExporter interface {
+	SpanExporter() (sdktrace.SpanExporter, error)
+}
+
+// OTelConfig configures the OpenTelemetry middleware.
+type OTelConfig struct {
+	// AppName is recorded as the resource's service.name attribute.
+	AppName string
+
+	// Version is recorded as the resource's service.version attribute.
+	Version string
+
+	// ServiceProvider identifies the hosting platform/environment (e.g.
+	// "aws", "gcp", "on-prem") and is recorded as a resource attribute.
+	ServiceProvider string
+
+	// Exporters is the set of destinations spans are fanned out to. With
+	// none, spans are still created but exported nowhere; use
+	// StdoutExporter() during development.
+	Exporters []TraceExporter
+
+	// Filter defines a function to skip middleware.
+	// Optional. Default: nil
+	Filter func(*fiber.Ctx) bool
+}
+
+// NewOTelMiddleware builds an sdktrace.TracerProvider from cfg.Exporters,
+// registers it as the global tracer provider alongside a W3C trace-context
+// propagator, and returns a Fiber middleware that starts a server-kind span
+// for every request. It is the OpenTelemetry counterpart to NewWithConfig.
+func NewOTelMiddleware(cfg OTelConfig) (fiber.Handler, error) {
+	res, err := sdkresource.New(
+		context.Background(),
+		sdkresource.WithAttributes(
+			semconv.ServiceName(cfg.AppName),
+			semconv.ServiceVersion(cfg.Version),
+			attribute.String("service.provider", cfg.ServiceProvider),
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := make([]sdktrace.TracerProviderOption, 0, len(cfg.Exporters)+1)
+	opts = append(opts, sdktrace.WithResource(res))
+
+	for _, e := range cfg.Exporters {
+		exp, err := e.SpanExporter()
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, sdktrace.WithBatcher(exp))
+	}
+
+	tracerProvider = sdktrace.NewTracerProvider(opts...)
+
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	tracer := tracerProvider.Tracer(DefaultComponentName)
+
+	return func(ctx *fiber.Ctx) error {
+		if cfg.Filter != nil && cfg.Filter(ctx) {
+			return ctx.Next()
+		}
+
+		hdr := propagation.MapCarrier{}
+		ctx.Request().Header.VisitAll(func(k, v []byte) {
+			hdr.Set(getString(k), getString(v))
+		})
+
+		parentCtx := otel.GetTextMapPropagator().Extract(ctx.UserContext(), hdr)
+
+		spanCtx, span := tracer.Start(
+			parentCtx,
+			ctx.Path(),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPMethod(ctx.Method()),
+				semconv.HTTPTarget(ctx.OriginalURL()),
+				semconv.NetHostName(ctx.Hostname()),
+				semconv.NetSockPeerAddr(ctx.IP()),
+			),
+		)
+		defer span.End()
+
+		ctx.Locals(DefaultOTelSpanKey, span)
+		ctx.SetUserContext(spanCtx)
+
+		err := ctx.Next()
+
+		status := ctx.Response().StatusCode()
+		span.SetAttributes(semconv.HTTPStatusCode(status))
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		} else if status >= fiber.StatusInternalServerError {
+			span.SetStatus(codes.Error, "")
+		}
+
+		return err
+	}, nil
+}
+
+// SpanFromContext returns the span stashed in ctx by the OTel middleware, or
+// a no-op span if the middleware was never run for this request.
+func SpanFromContext(ctx *fiber.Ctx) trace.Span {
+	if span, ok := ctx.Locals(DefaultOTelSpanKey).(trace.Span); ok {
+		return span
+	}
+
+	return trace.SpanFromContext(ctx.UserContext())
+}
+
+// ShutdownTracer flushes and shuts down the TracerProvider built by
+// NewOTelMiddleware. Callers should defer it in main alongside server
+// shutdown.
+func ShutdownTracer(ctx context.Context) error {
+	if tracerProvider == nil {
+		return nil
+	}
+
+	return tracerProvider.Shutdown(ctx)
+}
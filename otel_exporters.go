@@ -0,0 +1,72 @@
+package fiber_tracing
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// otlpHTTPExporter sends spans to an OTLP/HTTP collector, e.g. the OpenTelemetry
+// Collector or any vendor backend that speaks the OTLP HTTP protocol.
+type otlpHTTPExporter struct {
+	endpoint string
+	insecure bool
+}
+
+// OTLPHTTPExporter builds a TraceExporter that ships spans over OTLP/HTTP to
+// endpoint (host:port, no scheme). Set insecure to true to skip TLS, e.g.
+// when talking to a collector sidecar over plaintext.
+func OTLPHTTPExporter(endpoint string, insecure bool) TraceExporter {
+	return &otlpHTTPExporter{endpoint: endpoint, insecure: insecure}
+}
+
+func (e *otlpHTTPExporter) SpanExporter() (sdktrace.SpanExporter, error) {
+	opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(e.endpoint)}
+	if e.insecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+
+	return otlptracehttp.New(context.Background(), opts...)
+}
+
+// stdoutExporter pretty-prints spans to stdout, useful while developing locally.
+type stdoutExporter struct{}
+
+// StdoutExporter builds a TraceExporter that pretty-prints spans to stdout.
+func StdoutExporter() TraceExporter {
+	return &stdoutExporter{}
+}
+
+func (e *stdoutExporter) SpanExporter() (sdktrace.SpanExporter, error) {
+	return stdouttrace.New(stdouttrace.WithPrettyPrint())
+}
+
+// gelfExporter forwards spans as GELF messages to a Graylog input over UDP.
+type gelfExporter struct {
+	addr string
+}
+
+// GELFExporter builds a TraceExporter that ships spans as GELF messages to a
+// Graylog input listening at addr (host:port).
+func GELFExporter(addr string) TraceExporter {
+	return &gelfExporter{addr: addr}
+}
+
+func (e *gelfExporter) SpanExporter() (sdktrace.SpanExporter, error) {
+	conn, err := net.Dial("udp", e.addr)
+	if err != nil {
+		return nil, fmt.Errorf("fiber_tracing: dial gelf endpoint: %w", err)
+	}
+
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+
+	return &gelfSpanExporter{conn: conn, host: host}, nil
+}
@@ -0,0 +1,46 @@
+package fiber_tracing
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestRouteOrPathUsesRouteTemplateWhenMatched(t *testing.T) {
+	app := fiber.New()
+
+	var got string
+	app.Get("/users/:id", func(ctx *fiber.Ctx) error {
+		got = routeOrPath(ctx)
+		return nil
+	})
+
+	req := httptest.NewRequest("GET", "/users/123", nil)
+	if _, err := app.Test(req); err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+
+	if want := "/users/:id"; got != want {
+		t.Errorf("routeOrPath() = %q, want %q (low-cardinality template)", got, want)
+	}
+}
+
+func TestRouteTemplateOperationNameUsesTemplate(t *testing.T) {
+	app := fiber.New()
+
+	var got string
+	app.Get("/users/:id", func(ctx *fiber.Ctx) error {
+		got = routeTemplateOperationName(ctx)
+		return nil
+	})
+
+	req := httptest.NewRequest("GET", "/users/123", nil)
+	if _, err := app.Test(req); err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+
+	if want := "HTTP GET URL: /users/:id"; got != want {
+		t.Errorf("routeTemplateOperationName() = %q, want %q", got, want)
+	}
+}